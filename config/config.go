@@ -0,0 +1,49 @@
+// Package config loads the optional YAML configuration file used to tune
+// individual providers (result limits, page size, API keys) beyond what the
+// CLI flags expose.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Provider holds per-provider tuning knobs. Zero values mean "use the
+// provider's default".
+type Provider struct {
+	Limit    int    `yaml:"limit"`
+	PageSize int    `yaml:"pageSize"`
+	APIKey   string `yaml:"apiKey"`
+}
+
+// Config is the top-level shape of the YAML config file, keyed by provider
+// name (e.g. "otx", "wayback").
+type Config struct {
+	Providers map[string]Provider `yaml:"providers"`
+}
+
+// Load reads and parses the YAML config file at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Provider returns the tuning knobs for name, or the zero value if name has
+// no entry in the config.
+func (c *Config) Provider(name string) Provider {
+	if c == nil {
+		return Provider{}
+	}
+	return c.Providers[name]
+}
@@ -0,0 +1,66 @@
+package filter
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// extBlacklist drops URLs whose path extension is in the blocked set.
+type extBlacklist struct {
+	exts map[string]bool
+}
+
+// NewExtBlacklist drops any URL whose path extension (case-insensitive,
+// without the leading dot) is in exts.
+func NewExtBlacklist(exts []string) Filter {
+	return &extBlacklist{exts: toExtSet(exts)}
+}
+
+func (f *extBlacklist) Keep(_ context.Context, rawURL string) bool {
+	return !f.exts[pathExt(rawURL)]
+}
+
+// extWhitelist keeps only URLs whose path extension is in the allowed set.
+type extWhitelist struct {
+	exts map[string]bool
+}
+
+// NewExtWhitelist keeps only URLs whose path extension (case-insensitive,
+// without the leading dot) is in exts.
+func NewExtWhitelist(exts []string) Filter {
+	return &extWhitelist{exts: toExtSet(exts)}
+}
+
+func (f *extWhitelist) Keep(_ context.Context, rawURL string) bool {
+	return f.exts[pathExt(rawURL)]
+}
+
+func toExtSet(exts []string) map[string]bool {
+	set := make(map[string]bool, len(exts))
+	for _, e := range exts {
+		set[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(e), "."))] = true
+	}
+	return set
+}
+
+// pathExt returns the lowercase extension (without the dot) of rawURL's
+// final path segment, or "" if that segment has none or rawURL doesn't
+// parse as a URL.
+func pathExt(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	segment := u.Path
+	if i := strings.LastIndex(segment, "/"); i != -1 {
+		segment = segment[i+1:]
+	}
+
+	i := strings.LastIndex(segment, ".")
+	if i == -1 {
+		return ""
+	}
+	return strings.ToLower(segment[i+1:])
+}
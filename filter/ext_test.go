@@ -0,0 +1,46 @@
+package filter
+
+import "testing"
+
+func TestPathExt(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/app.js", "js"},
+		{"https://example.com/path/to/style.CSS", "css"},
+		{"https://example.com/no-extension", ""},
+		{"https://example.com/2023.12/index", ""},
+		{"https://example.com/2023.12/index.php", "php"},
+		{"https://example.com/", ""},
+		{"://not a url", ""},
+	}
+
+	for _, tt := range tests {
+		if got := pathExt(tt.url); got != tt.want {
+			t.Errorf("pathExt(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestExtBlacklistKeep(t *testing.T) {
+	f := NewExtBlacklist([]string{"png", "jpg"})
+
+	if f.Keep(nil, "https://example.com/image.png") {
+		t.Error("expected blacklisted extension to be dropped")
+	}
+	if !f.Keep(nil, "https://example.com/page.php") {
+		t.Error("expected non-blacklisted extension to be kept")
+	}
+}
+
+func TestExtWhitelistKeep(t *testing.T) {
+	f := NewExtWhitelist([]string{"php", "aspx"})
+
+	if !f.Keep(nil, "https://example.com/page.php") {
+		t.Error("expected whitelisted extension to be kept")
+	}
+	if f.Keep(nil, "https://example.com/image.png") {
+		t.Error("expected non-whitelisted extension to be dropped")
+	}
+}
@@ -0,0 +1,28 @@
+// Package filter implements the URL filtering pipeline: an ordered chain of
+// predicates run against every URL before it reaches the output writer, so
+// new predicates (extension, regex, status verification, ...) can be added
+// without touching the core collection loop.
+package filter
+
+import "context"
+
+// Filter decides whether a URL should be kept. ctx is threaded through so
+// filters that make network calls (e.g. Verifier) can be canceled along
+// with the rest of the run; filters with no I/O may ignore it.
+type Filter interface {
+	Keep(ctx context.Context, url string) bool
+}
+
+// Chain runs a list of Filters in order, short-circuiting on the first one
+// that rejects a URL.
+type Chain []Filter
+
+// Keep reports whether every filter in the chain keeps url.
+func (c Chain) Keep(ctx context.Context, url string) bool {
+	for _, f := range c {
+		if !f.Keep(ctx, url) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package filter
+
+import (
+	"context"
+	"regexp"
+)
+
+// matchRegex keeps only URLs that match a pattern (-match-regex).
+type matchRegex struct {
+	re *regexp.Regexp
+}
+
+// NewMatchRegex keeps only URLs matching re.
+func NewMatchRegex(re *regexp.Regexp) Filter {
+	return &matchRegex{re: re}
+}
+
+func (f *matchRegex) Keep(_ context.Context, rawURL string) bool {
+	return f.re.MatchString(rawURL)
+}
+
+// filterRegex drops URLs that match a pattern (-filter-regex).
+type filterRegex struct {
+	re *regexp.Regexp
+}
+
+// NewFilterRegex drops any URL matching re.
+func NewFilterRegex(re *regexp.Regexp) Filter {
+	return &filterRegex{re: re}
+}
+
+func (f *filterRegex) Keep(_ context.Context, rawURL string) bool {
+	return !f.re.MatchString(rawURL)
+}
@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"context"
+	"net/http"
+)
+
+// Verifier drops URLs whose HEAD response status isn't in an allowed set.
+// It performs the request itself, so its concurrency is bounded by however
+// many goroutines call Keep at once rather than by anything internal to the
+// filter.
+type Verifier struct {
+	client  *http.Client
+	allowed map[int]bool
+}
+
+// NewVerifier returns a Filter that HEAD-requests every URL through client
+// and keeps it only if the response status is in allowedCodes.
+func NewVerifier(client *http.Client, allowedCodes []int) *Verifier {
+	allowed := make(map[int]bool, len(allowedCodes))
+	for _, c := range allowedCodes {
+		allowed[c] = true
+	}
+	return &Verifier{client: client, allowed: allowed}
+}
+
+func (v *Verifier) Keep(ctx context.Context, rawURL string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+
+	return v.allowed[resp.StatusCode]
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/mrco24/otx-url/config"
+	"github.com/mrco24/otx-url/filter"
+	"github.com/mrco24/otx-url/output"
+	"github.com/mrco24/otx-url/providers"
+	"github.com/mrco24/otx-url/runner"
+	"github.com/mrco24/otx-url/store"
+)
+
+func main() {
+	// Define command-line flags
+	singleSubdomain := flag.String("u", "", "Specify a single subdomain")
+	subdomainFile := flag.String("l", "", "Specify a file containing multiple subdomains")
+	outputFile := flag.String("o", "", "Specify an output file for the results")
+	threads := flag.Int("t", 1, "Specify the number of worker goroutines")
+	providerList := flag.String("providers", "otx", "Comma-separated providers to query (otx,wayback,commoncrawl,urlscan)")
+	configFile := flag.String("config", "", "Path to a YAML config file for per-provider tuning")
+	timeout := flag.Duration("timeout", 30*time.Second, "Per-request HTTP timeout for the OTX provider")
+	retries := flag.Int("retries", 3, "Retries on 429/5xx responses for the OTX provider")
+	pageSize := flag.Int("page-size", 0, "Results requested per page from the OTX provider (falls back to the config file's pageSize, then 100)")
+	proxyAddr := flag.String("proxy", "", "Proxy for OTX requests: http://host:port or socks5://host:port")
+	format := flag.String("format", "plain", "Output format: plain, jsonl, or csv")
+	blacklistExt := flag.String("blacklist-ext", "", "Comma-separated extensions to drop, e.g. png,jpg,css,woff")
+	whitelistExt := flag.String("whitelist-ext", "", "Comma-separated extensions to keep, e.g. php,aspx")
+	matchRegex := flag.String("match-regex", "", "Keep only URLs matching this regex")
+	filterRegex := flag.String("filter-regex", "", "Drop URLs matching this regex")
+	verify := flag.Bool("verify", false, "HEAD-request every URL and drop ones outside -mc")
+	matchCodes := flag.String("mc", "200,301,302", "Comma-separated status codes kept when -verify is set")
+	verifyConcurrency := flag.Int("verify-concurrency", 20, "Concurrent HEAD requests when -verify is set")
+	resumeFile := flag.String("resume", "", "Path to a state file; skips completed (domain, provider) pairs and already-emitted URLs from a prior run")
+	flag.Parse()
+
+	// Check flag combinations
+	if (*singleSubdomain == "" && *subdomainFile == "") || (*singleSubdomain != "" && *subdomainFile != "") {
+		fmt.Println("Specify either a single subdomain with -u or a subdomain file with -l")
+		return
+	}
+
+	// Read subdomains based on flags
+	var subdomains []string
+	if *singleSubdomain != "" {
+		subdomains = append(subdomains, *singleSubdomain)
+	} else {
+		subdomainsFromFile, err := readSubdomains(*subdomainFile)
+		if err != nil {
+			fmt.Println("Error reading subdomains:", err)
+			return
+		}
+		subdomains = subdomainsFromFile
+	}
+
+	var cfg *config.Config
+	if *configFile != "" {
+		c, err := config.Load(*configFile)
+		if err != nil {
+			fmt.Println("Error loading config:", err)
+			return
+		}
+		cfg = c
+	}
+
+	transport, err := providers.NewTransport(*proxyAddr)
+	if err != nil {
+		fmt.Println("Error configuring proxy:", err)
+		return
+	}
+
+	otxOpts := providers.OTXOptions{
+		Timeout:   *timeout,
+		Retries:   *retries,
+		PageSize:  *pageSize,
+		Transport: transport,
+	}
+
+	provs, err := resolveProviders(*providerList, cfg, otxOpts)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	chain, err := buildFilterChain(filterFlags{
+		blacklistExt: *blacklistExt,
+		whitelistExt: *whitelistExt,
+		matchRegex:   *matchRegex,
+		filterRegex:  *filterRegex,
+		verify:       *verify,
+		matchCodes:   *matchCodes,
+	}, transport)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var resumeStore *store.Store
+	if *resumeFile != "" {
+		s, err := store.Open(*resumeFile)
+		if err != nil {
+			fmt.Println("Error opening resume state:", err)
+			return
+		}
+		defer s.Close()
+		resumeStore = s
+	}
+
+	r := runner.New(provs, *threads)
+	if resumeStore != nil {
+		r.Store = resumeStore
+	}
+
+	// Cancel in-flight requests promptly on Ctrl-C instead of letting the
+	// process hang until every worker's current request times out.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dest := io.Writer(os.Stdout)
+	if *outputFile != "" {
+		f, err := os.Create(*outputFile)
+		if err != nil {
+			fmt.Println("Error creating output file:", err)
+			return
+		}
+		defer f.Close()
+		dest = f
+	}
+
+	writer, err := output.New(dest, output.Format(*format))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	results := r.Run(ctx, subdomains)
+	if *verify {
+		results = filterConcurrently(ctx, results, chain, *verifyConcurrency)
+	}
+
+	seen := make(map[string]bool)
+	for res := range results {
+		if len(chain) > 0 && !*verify && !chain.Keep(ctx, res.URL) {
+			continue
+		}
+		key := res.Domain + " " + res.URL
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if resumeStore != nil && resumeStore.SeenURL(res.Domain, res.URL) {
+			continue
+		}
+		writer.Write(output.Record{
+			Subdomain: res.Domain,
+			URL:       res.URL,
+			Source:    res.Provider,
+			FirstSeen: time.Now(),
+		})
+	}
+	writer.Close()
+}
+
+// filterConcurrently applies chain.Keep through a bounded pool of workers,
+// since -verify makes Keep issue a network HEAD request per URL and running
+// those serially would stall collection on network latency.
+func filterConcurrently(ctx context.Context, in <-chan runner.Result, chain filter.Chain, workers int) <-chan runner.Result {
+	if workers < 1 {
+		workers = 1
+	}
+
+	out := make(chan runner.Result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for res := range in {
+				if !chain.Keep(ctx, res.URL) {
+					continue
+				}
+				select {
+				case out <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// filterFlags groups the raw -blacklist-ext/-whitelist-ext/... flag values
+// so buildFilterChain doesn't need a long positional parameter list.
+type filterFlags struct {
+	blacklistExt string
+	whitelistExt string
+	matchRegex   string
+	filterRegex  string
+	verify       bool
+	matchCodes   string
+}
+
+// buildFilterChain assembles the ordered filter.Chain described by f. The
+// -verify filter, if enabled, issues its HEAD requests through transport so
+// verification traffic takes the same egress path (proxy included) as
+// discovery traffic.
+func buildFilterChain(f filterFlags, transport *http.Transport) (filter.Chain, error) {
+	var chain filter.Chain
+
+	if f.blacklistExt != "" {
+		chain = append(chain, filter.NewExtBlacklist(strings.Split(f.blacklistExt, ",")))
+	}
+	if f.whitelistExt != "" {
+		chain = append(chain, filter.NewExtWhitelist(strings.Split(f.whitelistExt, ",")))
+	}
+	if f.matchRegex != "" {
+		re, err := regexp.Compile(f.matchRegex)
+		if err != nil {
+			return nil, fmt.Errorf("parsing -match-regex: %w", err)
+		}
+		chain = append(chain, filter.NewMatchRegex(re))
+	}
+	if f.filterRegex != "" {
+		re, err := regexp.Compile(f.filterRegex)
+		if err != nil {
+			return nil, fmt.Errorf("parsing -filter-regex: %w", err)
+		}
+		chain = append(chain, filter.NewFilterRegex(re))
+	}
+	if f.verify {
+		codes, err := parseStatusCodes(f.matchCodes)
+		if err != nil {
+			return nil, err
+		}
+		client := &http.Client{Timeout: 10 * time.Second, Transport: transport}
+		chain = append(chain, filter.NewVerifier(client, codes))
+	}
+
+	return chain, nil
+}
+
+func parseStatusCodes(list string) ([]int, error) {
+	parts := strings.Split(list, ",")
+	codes := make([]int, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		c, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -mc status code %q", p)
+		}
+		codes = append(codes, c)
+	}
+	return codes, nil
+}
+
+// resolveProviders turns a comma-separated -providers flag value into the
+// matching Provider implementations, preserving the order the caller listed
+// them in.
+func resolveProviders(list string, cfg *config.Config, otxOpts providers.OTXOptions) ([]providers.Provider, error) {
+	all := providers.All(cfg, otxOpts)
+
+	names := strings.Split(list, ",")
+	provs := make([]providers.Provider, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		p, ok := all[name]
+		if !ok {
+			known := make([]string, 0, len(all))
+			for k := range all {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown provider %q (known: %s)", name, strings.Join(known, ", "))
+		}
+		provs = append(provs, p)
+	}
+
+	if len(provs) == 0 {
+		return nil, fmt.Errorf("no providers enabled")
+	}
+
+	return provs, nil
+}
+
+func readSubdomains(filename string) ([]string, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	subdomains := strings.Fields(string(content))
+	return subdomains, nil
+}
@@ -0,0 +1,111 @@
+// Package output serializes discovered URLs to a destination stream. A
+// single goroutine owns the stream, so producers never need to coordinate
+// their own synchronization around it.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Format selects how Records are serialized.
+type Format string
+
+const (
+	Plain Format = "plain"
+	JSONL Format = "jsonl"
+	CSV   Format = "csv"
+)
+
+// Record is one URL discovered for a subdomain by a provider.
+type Record struct {
+	Subdomain string
+	URL       string
+	Source    string
+	FirstSeen time.Time
+}
+
+type jsonRecord struct {
+	Subdomain string `json:"subdomain"`
+	URL       string `json:"url"`
+	Source    string `json:"source"`
+	FirstSeen string `json:"first_seen"`
+}
+
+// Writer serializes every Record passed to Write through a single goroutine
+// that owns the destination stream.
+type Writer struct {
+	records chan Record
+	done    chan struct{}
+}
+
+// New starts the writer goroutine, encoding Records to w as format. Callers
+// must call Close once done to flush and wait for pending records.
+func New(w io.Writer, format Format) (*Writer, error) {
+	encode, err := encoderFor(w, format)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &Writer{
+		records: make(chan Record),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(writer.done)
+		for r := range writer.records {
+			encode(r)
+		}
+	}()
+
+	return writer, nil
+}
+
+// Write enqueues r for the writer goroutine. Safe for concurrent use.
+func (w *Writer) Write(r Record) {
+	w.records <- r
+}
+
+// Close stops accepting new records and blocks until the writer goroutine
+// has drained and flushed everything already enqueued.
+func (w *Writer) Close() {
+	close(w.records)
+	<-w.done
+}
+
+// encoderFor returns a function that serializes one Record to w in the
+// given format.
+func encoderFor(w io.Writer, format Format) (func(Record), error) {
+	switch format {
+	case Plain, "":
+		return func(r Record) {
+			fmt.Fprintln(w, r.URL)
+		}, nil
+
+	case JSONL:
+		enc := json.NewEncoder(w)
+		return func(r Record) {
+			_ = enc.Encode(jsonRecord{
+				Subdomain: r.Subdomain,
+				URL:       r.URL,
+				Source:    r.Source,
+				FirstSeen: r.FirstSeen.UTC().Format(time.RFC3339),
+			})
+		}, nil
+
+	case CSV:
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"subdomain", "url", "source", "first_seen"})
+		return func(r Record) {
+			_ = cw.Write([]string{r.Subdomain, r.URL, r.Source, r.FirstSeen.UTC().Format(time.RFC3339)})
+			cw.Flush()
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown format %q (want plain, jsonl, or csv)", format)
+	}
+}
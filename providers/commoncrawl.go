@@ -0,0 +1,84 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/mrco24/otx-url/config"
+)
+
+// commonCrawlIndex is the CDX index queried for URLs. Common Crawl publishes
+// a new index roughly monthly; this pins a recent one rather than resolving
+// the collinfo.json list on every run.
+const commonCrawlIndex = "CC-MAIN-2024-10"
+
+// CommonCrawl queries Common Crawl's CDX index API for URLs captured under a
+// domain.
+type CommonCrawl struct {
+	cfg config.Provider
+}
+
+// NewCommonCrawl returns a Common Crawl provider tuned with cfg.
+func NewCommonCrawl(cfg config.Provider) *CommonCrawl {
+	return &CommonCrawl{cfg: cfg}
+}
+
+func (p *CommonCrawl) Name() string {
+	return "commoncrawl"
+}
+
+func (p *CommonCrawl) Fetch(ctx context.Context, domain string) (<-chan string, error) {
+	reqURL := fmt.Sprintf("https://index.commoncrawl.org/%s-index?url=*.%s&output=json", commonCrawlIndex, domain)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan string)
+
+	go func() {
+		defer close(out)
+		defer response.Body.Close()
+
+		// The index API returns newline-delimited JSON objects, not a
+		// single JSON array.
+		sent := 0
+		scanner := bufio.NewScanner(response.Body)
+		for scanner.Scan() {
+			if limitReached(sent, p.cfg.Limit) {
+				return
+			}
+
+			var entry struct {
+				URL string `json:"url"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				continue
+			}
+			if entry.URL == "" {
+				continue
+			}
+			select {
+			case out <- entry.URL:
+				sent++
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Printf("commoncrawl: %s: %v", domain, err)
+		}
+	}()
+
+	return out, nil
+}
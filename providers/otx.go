@@ -0,0 +1,189 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/mrco24/otx-url/config"
+)
+
+// defaultOTXPageSize mirrors the page size the previous single-page
+// implementation hardcoded.
+const defaultOTXPageSize = 100
+
+// OTXOptions tunes the HTTP behaviour of the OTX provider.
+type OTXOptions struct {
+	// Timeout bounds a single page request.
+	Timeout time.Duration
+	// Retries is how many times a page is retried after a 429 or 5xx
+	// response, with exponential backoff between attempts.
+	Retries int
+	// PageSize is the number of results requested per page. Zero uses
+	// defaultOTXPageSize.
+	PageSize int
+	// Transport is the round tripper used for every request, e.g. one
+	// built by NewTransport to route through a proxy. Nil uses the
+	// *http.Client default.
+	Transport *http.Transport
+}
+
+// OTX queries AlienVault OTX's hostname URL list for a domain, paginating
+// through every page rather than only the first.
+type OTX struct {
+	cfg    config.Provider
+	client *http.Client
+	opts   OTXOptions
+}
+
+// NewOTX returns an OTX provider tuned with cfg and opts. opts.PageSize (the
+// -page-size flag) wins when set; otherwise cfg.PageSize (the config file)
+// is used; otherwise defaultOTXPageSize.
+func NewOTX(cfg config.Provider, opts OTXOptions) *OTX {
+	if opts.PageSize == 0 {
+		opts.PageSize = cfg.PageSize
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = defaultOTXPageSize
+	}
+	client := &http.Client{Timeout: opts.Timeout}
+	if opts.Transport != nil {
+		client.Transport = opts.Transport
+	}
+	return &OTX{
+		cfg:    cfg,
+		client: client,
+		opts:   opts,
+	}
+}
+
+func (p *OTX) Name() string {
+	return "otx"
+}
+
+// Fetch walks every page of the OTX hostname URL list for domain, streaming
+// each URL as soon as its page is decoded rather than buffering the whole
+// result set in memory.
+func (p *OTX) Fetch(ctx context.Context, domain string) (<-chan string, error) {
+	out := make(chan string)
+
+	go func() {
+		defer close(out)
+
+		sent := 0
+		for page := 1; ; page++ {
+			result, err := p.fetchPage(ctx, domain, page)
+			if err != nil {
+				log.Printf("otx: %s: page %d: %v", domain, page, err)
+				return
+			}
+
+			for _, u := range result.urls {
+				if limitReached(sent, p.cfg.Limit) {
+					return
+				}
+				select {
+				case out <- u:
+					sent++
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !result.hasNext || len(result.urls) == 0 {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+type otxPage struct {
+	urls    []string
+	hasNext bool
+}
+
+// fetchPage requests a single page, retrying on 429/5xx with exponential
+// backoff before giving up.
+func (p *OTX) fetchPage(ctx context.Context, domain string, page int) (otxPage, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= p.opts.Retries; attempt++ {
+		if attempt > 0 {
+			backoff := jitter(time.Duration(1<<uint(attempt-1)) * time.Second)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return otxPage{}, ctx.Err()
+			}
+		}
+
+		result, retryable, err := p.requestPage(ctx, domain, page)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if !retryable {
+			return otxPage{}, err
+		}
+	}
+
+	return otxPage{}, lastErr
+}
+
+// requestPage issues a single attempt at a page. The second return value
+// reports whether the error (if any) is worth retrying.
+func (p *OTX) requestPage(ctx context.Context, domain string, page int) (otxPage, bool, error) {
+	reqURL := fmt.Sprintf("https://otx.alienvault.com/otxapi/indicator/hostname/url_list/%s?limit=%d&page=%d", domain, p.opts.PageSize, page)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return otxPage{}, false, err
+	}
+
+	response, err := p.client.Do(req)
+	if err != nil {
+		return otxPage{}, true, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500 {
+		return otxPage{}, true, fmt.Errorf("otx: page %d: status %d", page, response.StatusCode)
+	}
+	if response.StatusCode != http.StatusOK {
+		return otxPage{}, false, fmt.Errorf("otx: page %d: status %d", page, response.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return otxPage{}, true, err
+	}
+
+	var data struct {
+		HasNext bool                     `json:"has_next"`
+		URLList []map[string]interface{} `json:"url_list"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return otxPage{}, false, err
+	}
+
+	urls := make([]string, 0, len(data.URLList))
+	for _, entry := range data.URLList {
+		if u, ok := entry["url"].(string); ok {
+			urls = append(urls, u)
+		}
+	}
+
+	return otxPage{urls: urls, hasNext: data.HasNext}, false, nil
+}
+
+// jitter avoids every worker retrying a rate-limited endpoint in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
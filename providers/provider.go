@@ -0,0 +1,36 @@
+// Package providers implements the URL-discovery sources (OTX, Wayback
+// Machine, Common Crawl, URLScan) behind a common Provider interface so the
+// runner can fan work out to any combination of them.
+package providers
+
+import (
+	"context"
+
+	"github.com/mrco24/otx-url/config"
+)
+
+// Provider discovers URLs seen for a domain from a single upstream source.
+// Fetch streams results on the returned channel and closes it once the
+// source is exhausted or ctx is canceled.
+type Provider interface {
+	Name() string
+	Fetch(ctx context.Context, domain string) (<-chan string, error)
+}
+
+// All returns every provider known to the package, keyed by Name(), tuned
+// with whatever per-provider config was supplied (a nil cfg yields defaults
+// for every provider) and otxOpts for OTX's HTTP behaviour.
+func All(cfg *config.Config, otxOpts OTXOptions) map[string]Provider {
+	return map[string]Provider{
+		"otx":         NewOTX(cfg.Provider("otx"), otxOpts),
+		"wayback":     NewWayback(cfg.Provider("wayback")),
+		"commoncrawl": NewCommonCrawl(cfg.Provider("commoncrawl")),
+		"urlscan":     NewURLScan(cfg.Provider("urlscan")),
+	}
+}
+
+// limitReached reports whether sent has hit a provider's configured result
+// limit. limit <= 0 means unlimited.
+func limitReached(sent, limit int) bool {
+	return limit > 0 && sent >= limit
+}
@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/proxy"
+)
+
+// NewTransport builds an *http.Transport for the OTX provider, optionally
+// routed through proxyAddr. proxyAddr accepts http://host:port (or
+// https://host:port) and socks5://host:port; an empty proxyAddr returns a
+// plain transport. This lets OTX lookups be routed through Tor or a
+// corporate egress proxy.
+func NewTransport(proxyAddr string) (*http.Transport, error) {
+	transport := &http.Transport{}
+	if proxyAddr == "" {
+		return transport, nil
+	}
+
+	u, err := url.Parse(proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("parsing proxy address: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		transport.Proxy = http.ProxyURL(u)
+	case "socks5":
+		dialer, err := proxy.FromURL(u, proxy.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("building socks5 dialer: %w", err)
+		}
+		contextDialer, ok := dialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("socks5 dialer does not support DialContext")
+		}
+		transport.DialContext = contextDialer.DialContext
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use http:// or socks5://)", u.Scheme)
+	}
+
+	// Proxies here (Tor in particular) generally only speak HTTP/1.1; an
+	// empty TLSNextProto stops the transport from negotiating HTTP/2 and
+	// getting stuck.
+	transport.TLSNextProto = make(map[string]func(authority string, c *tls.Conn) http.RoundTripper)
+
+	return transport, nil
+}
@@ -0,0 +1,53 @@
+package providers
+
+import "testing"
+
+func TestNewTransport(t *testing.T) {
+	t.Run("empty proxy returns plain transport", func(t *testing.T) {
+		transport, err := NewTransport("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if transport.Proxy != nil {
+			t.Error("expected no proxy configured")
+		}
+		if transport.DialContext != nil {
+			t.Error("expected default dialer")
+		}
+	})
+
+	t.Run("http proxy sets Proxy", func(t *testing.T) {
+		transport, err := NewTransport("http://127.0.0.1:8080")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if transport.Proxy == nil {
+			t.Error("expected Proxy to be set")
+		}
+	})
+
+	t.Run("socks5 proxy sets DialContext", func(t *testing.T) {
+		transport, err := NewTransport("socks5://127.0.0.1:9050")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if transport.DialContext == nil {
+			t.Error("expected DialContext to be set")
+		}
+		if transport.TLSNextProto == nil {
+			t.Error("expected HTTP/2 to be disabled for a proxied transport")
+		}
+	})
+
+	t.Run("unsupported scheme errors", func(t *testing.T) {
+		if _, err := NewTransport("ftp://127.0.0.1:21"); err == nil {
+			t.Error("expected an error for an unsupported scheme")
+		}
+	})
+
+	t.Run("unparseable address errors", func(t *testing.T) {
+		if _, err := NewTransport("://bad"); err == nil {
+			t.Error("expected an error for an unparseable address")
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/mrco24/otx-url/config"
+)
+
+// URLScan queries urlscan.io's search API for URLs scanned under a domain.
+type URLScan struct {
+	cfg config.Provider
+}
+
+// NewURLScan returns a urlscan.io provider tuned with cfg.
+func NewURLScan(cfg config.Provider) *URLScan {
+	return &URLScan{cfg: cfg}
+}
+
+func (p *URLScan) Name() string {
+	return "urlscan"
+}
+
+func (p *URLScan) Fetch(ctx context.Context, domain string) (<-chan string, error) {
+	out := make(chan string)
+
+	go func() {
+		defer close(out)
+
+		urls, err := getURLScanURLs(ctx, domain, p.cfg.APIKey)
+		if err != nil {
+			log.Printf("urlscan: %s: %v", domain, err)
+			return
+		}
+		for i, u := range urls {
+			if limitReached(i, p.cfg.Limit) {
+				return
+			}
+			select {
+			case out <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func getURLScanURLs(ctx context.Context, domain, apiKey string) ([]string, error) {
+	reqURL := fmt.Sprintf("https://urlscan.io/api/v1/search/?q=domain:%s", domain)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey != "" {
+		req.Header.Set("API-Key", apiKey)
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Results []struct {
+			Page struct {
+				URL string `json:"url"`
+			} `json:"page"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+
+	urls := []string{}
+	for _, r := range data.Results {
+		if r.Page.URL != "" {
+			urls = append(urls, r.Page.URL)
+		}
+	}
+
+	return urls, nil
+}
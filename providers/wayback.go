@@ -0,0 +1,90 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/mrco24/otx-url/config"
+)
+
+// Wayback queries the Wayback Machine's CDX API for URLs captured under a
+// domain.
+type Wayback struct {
+	cfg config.Provider
+}
+
+// NewWayback returns a Wayback Machine provider tuned with cfg.
+func NewWayback(cfg config.Provider) *Wayback {
+	return &Wayback{cfg: cfg}
+}
+
+func (p *Wayback) Name() string {
+	return "wayback"
+}
+
+func (p *Wayback) Fetch(ctx context.Context, domain string) (<-chan string, error) {
+	out := make(chan string)
+
+	go func() {
+		defer close(out)
+
+		urls, err := getWaybackURLs(ctx, domain)
+		if err != nil {
+			log.Printf("wayback: %s: %v", domain, err)
+			return
+		}
+		for i, u := range urls {
+			if limitReached(i, p.cfg.Limit) {
+				return
+			}
+			select {
+			case out <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func getWaybackURLs(ctx context.Context, domain string) ([]string, error) {
+	reqURL := fmt.Sprintf("https://web.archive.org/cdx/search/cdx?url=*.%s&output=json&fl=original&collapse=urlkey", domain)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The CDX API returns a JSON array of rows, the first of which is the
+	// column header (["original"]) rather than a data row.
+	var rows [][]string
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, err
+	}
+
+	urls := []string{}
+	for i, row := range rows {
+		if i == 0 || len(row) == 0 {
+			continue
+		}
+		urls = append(urls, row[0])
+	}
+
+	return urls, nil
+}
@@ -0,0 +1,123 @@
+// Package runner fans a set of domains out across a set of providers using a
+// fixed-size worker pool pulling from a shared task queue, rather than
+// spawning a goroutine per domain-provider pair. This keeps concurrency
+// bounded when a large `-l` list is combined with many providers.
+package runner
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/mrco24/otx-url/providers"
+)
+
+// task is a single (domain, provider) unit of work.
+type task struct {
+	domain   string
+	provider providers.Provider
+}
+
+// Result is a URL discovered for a domain by a named provider.
+type Result struct {
+	Domain   string
+	Provider string
+	URL      string
+}
+
+// TaskStore lets the runner skip (domain, provider) work already completed
+// in a previous run and checkpoint newly completed work for the next one.
+type TaskStore interface {
+	Done(domain, provider string) bool
+	MarkDone(domain, provider string, lastPage int)
+}
+
+// Runner fans domains out across providers via a fixed pool of workers.
+type Runner struct {
+	Providers []providers.Provider
+	Workers   int
+	// Store, if set, is consulted to skip already-completed tasks and
+	// updated as tasks finish. Nil disables resume behavior.
+	Store TaskStore
+}
+
+// New returns a Runner that fans work across provs using the given number of
+// worker goroutines. workers is clamped to at least 1.
+func New(provs []providers.Provider, workers int) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Runner{Providers: provs, Workers: workers}
+}
+
+// Run enqueues one task per (domain, provider) pair and streams results on
+// the returned channel. The channel is closed once every task has completed
+// or ctx is canceled.
+func (r *Runner) Run(ctx context.Context, domains []string) <-chan Result {
+	tasks := make(chan task)
+	results := make(chan Result)
+
+	go func() {
+		defer close(tasks)
+		for _, domain := range domains {
+			for _, p := range r.Providers {
+				if r.Store != nil && r.Store.Done(domain, p.Name()) {
+					continue
+				}
+				select {
+				case tasks <- task{domain: domain, provider: p}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx, tasks, results)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// work pulls tasks from the queue until it is drained or ctx is canceled,
+// forwarding every URL each provider yields to results.
+func (r *Runner) work(ctx context.Context, tasks <-chan task, results chan<- Result) {
+	for t := range tasks {
+		urls, err := t.provider.Fetch(ctx, t.domain)
+		if err != nil {
+			log.Printf("%s: %s: %v", t.provider.Name(), t.domain, err)
+			continue
+		}
+
+		completed := true
+	drain:
+		for url := range urls {
+			select {
+			case results <- Result{Domain: t.domain, Provider: t.provider.Name(), URL: url}:
+			case <-ctx.Done():
+				completed = false
+				break drain
+			}
+		}
+
+		// A provider also closes its channel when ctx is canceled, so a
+		// normally ended drain does not by itself mean the task finished.
+		if !completed || ctx.Err() != nil {
+			return
+		}
+		if r.Store != nil {
+			r.Store.MarkDone(t.domain, t.provider.Name(), 0)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// bloomBits is the fixed filter size: 1<<24 bits (2MB) gives a low false
+// positive rate well past the URL counts a single recon run produces.
+const bloomBits = 1 << 24
+const bloomHashes = 4
+
+// bloom is a fixed-size Bloom filter used to remember URLs emitted by
+// earlier runs. A positive Test result may be a false positive (a URL
+// wrongly treated as already seen); a negative result is never wrong.
+type bloom struct {
+	bits []uint64
+}
+
+func newBloom() *bloom {
+	return &bloom{bits: make([]uint64, bloomBits/64)}
+}
+
+func (b *bloom) indexes(s string) [bloomHashes]uint64 {
+	h1 := fnv.New64a()
+	h1.Write([]byte(s))
+	sum1 := h1.Sum64()
+
+	h2 := fnv.New64()
+	h2.Write([]byte(s))
+	sum2 := h2.Sum64()
+
+	var idx [bloomHashes]uint64
+	for i := range idx {
+		idx[i] = (sum1 + uint64(i)*sum2) % bloomBits
+	}
+	return idx
+}
+
+// Test reports whether s was previously added.
+func (b *bloom) Test(s string) bool {
+	for _, i := range b.indexes(s) {
+		if b.bits[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Add records s as seen.
+func (b *bloom) Add(s string) {
+	for _, i := range b.indexes(s) {
+		b.bits[i/64] |= 1 << (i % 64)
+	}
+}
+
+func (b *bloom) marshal() []byte {
+	buf := make([]byte, len(b.bits)*8)
+	for i, word := range b.bits {
+		binary.LittleEndian.PutUint64(buf[i*8:], word)
+	}
+	return buf
+}
+
+func (b *bloom) unmarshal(data []byte) {
+	for i := range b.bits {
+		start := i * 8
+		if start+8 > len(data) {
+			return
+		}
+		b.bits[i] = binary.LittleEndian.Uint64(data[start : start+8])
+	}
+}
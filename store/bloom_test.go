@@ -0,0 +1,44 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBloomNoFalseNegatives(t *testing.T) {
+	b := newBloom()
+
+	added := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		s := fmt.Sprintf("https://example.com/path/%d", i)
+		added = append(added, s)
+		b.Add(s)
+	}
+
+	for _, s := range added {
+		if !b.Test(s) {
+			t.Fatalf("bloom filter false negative for %q", s)
+		}
+	}
+}
+
+func TestBloomMarshalUnmarshalRoundTrip(t *testing.T) {
+	b := newBloom()
+	b.Add("https://example.com/a")
+	b.Add("https://example.com/b")
+
+	data := b.marshal()
+
+	restored := newBloom()
+	restored.unmarshal(data)
+
+	if !restored.Test("https://example.com/a") {
+		t.Error("expected restored filter to recognize previously added URL a")
+	}
+	if !restored.Test("https://example.com/b") {
+		t.Error("expected restored filter to recognize previously added URL b")
+	}
+	if restored.Test("https://example.com/never-added") {
+		t.Error("expected restored filter to not recognize a URL that was never added")
+	}
+}
@@ -0,0 +1,165 @@
+// Package store persists checkpoint state to a BoltDB file so a large,
+// multi-hour run against thousands of subdomains can be resumed with
+// `-resume` after a crash instead of starting from scratch.
+package store
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	tasksBucket = []byte("tasks")
+	metaBucket  = []byte("meta")
+	bloomKey    = []byte("bloom")
+)
+
+// bloomFlushInterval is how many new URLs accumulate in the in-memory bloom
+// filter before it's persisted, so a crash mid-run loses at most this many
+// URLs' worth of dedupe state instead of the whole run's.
+const bloomFlushInterval = 500
+
+// TaskState records how a completed (domain, provider) task finished.
+type TaskState struct {
+	// LastPage is reserved for providers that expose page-level progress;
+	// no Provider implementation populates it yet, so it is always 0.
+	LastPage    int       `json:"last_page"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// Store is the on-disk checkpoint: which (domain, provider) tasks finished,
+// and a bloom filter of every (domain, URL) pair already emitted.
+type Store struct {
+	db *bbolt.DB
+
+	mu    sync.Mutex
+	seen  *bloom
+	dirty int // URLs added to seen since the bloom filter was last persisted
+}
+
+// Open opens (creating if necessary) the BoltDB file at path and loads any
+// previously persisted state.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{db: db, seen: newBloom()}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(tasksBucket); err != nil {
+			return err
+		}
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+		if data := meta.Get(bloomKey); data != nil {
+			s.seen.unmarshal(data)
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close persists the bloom filter and closes the underlying file.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	data := s.seen.marshal()
+	s.mu.Unlock()
+
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(metaBucket).Put(bloomKey, data)
+	}); err != nil {
+		s.db.Close()
+		return err
+	}
+
+	return s.db.Close()
+}
+
+func taskKey(domain, provider string) []byte {
+	return []byte(domain + "|" + provider)
+}
+
+// seenKey combines domain and url into the bloom filter's entry key, so the
+// same URL seen under two different subdomains is tracked as two distinct
+// entries, matching the in-run (domain, URL) dedupe in main.go.
+func seenKey(domain, url string) string {
+	return domain + "|" + url
+}
+
+// Done reports whether (domain, provider) completed in a previous run.
+func (s *Store) Done(domain, provider string) bool {
+	var done bool
+	_ = s.db.View(func(tx *bbolt.Tx) error {
+		done = tx.Bucket(tasksBucket).Get(taskKey(domain, provider)) != nil
+		return nil
+	})
+	return done
+}
+
+// MarkDone records (domain, provider) as completed and persists the bloom
+// filter's current state in the same transaction, so a crash right after a
+// task finishes doesn't lose the dedupe state for the URLs it emitted.
+func (s *Store) MarkDone(domain, provider string, lastPage int) {
+	data, err := json.Marshal(TaskState{LastPage: lastPage, CompletedAt: time.Now()})
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	bloomData := s.seen.marshal()
+	s.dirty = 0
+	s.mu.Unlock()
+
+	_ = s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(tasksBucket).Put(taskKey(domain, provider), data); err != nil {
+			return err
+		}
+		return tx.Bucket(metaBucket).Put(bloomKey, bloomData)
+	})
+}
+
+// SeenURL reports whether (domain, url) was emitted in a previous run (or
+// already in this one), and records it as seen either way. Keying on the
+// pair rather than url alone matches the in-run dedupe in main.go, so the
+// same URL surfaced under two different subdomains is suppressed in neither
+// run or both, never just the resumed one. The bloom filter is persisted
+// every bloomFlushInterval additions rather than only on Close, so a crash
+// mid-run loses at most one interval's worth of dedupe state.
+func (s *Store) SeenURL(domain, url string) bool {
+	key := seenKey(domain, url)
+
+	s.mu.Lock()
+	if s.seen.Test(key) {
+		s.mu.Unlock()
+		return true
+	}
+	s.seen.Add(key)
+	s.dirty++
+
+	var flushData []byte
+	if s.dirty >= bloomFlushInterval {
+		flushData = s.seen.marshal()
+		s.dirty = 0
+	}
+	s.mu.Unlock()
+
+	if flushData != nil {
+		_ = s.db.Update(func(tx *bbolt.Tx) error {
+			return tx.Bucket(metaBucket).Put(bloomKey, flushData)
+		})
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestSeenURLIsKeyedByDomain(t *testing.T) {
+	s := openTestStore(t)
+
+	const url = "https://cdn.example.com/shared/asset.js"
+
+	if s.SeenURL("a.example.com", url) {
+		t.Fatal("expected first sighting under a.example.com to be unseen")
+	}
+	if !s.SeenURL("a.example.com", url) {
+		t.Fatal("expected repeat sighting under a.example.com to be seen")
+	}
+	if s.SeenURL("b.example.com", url) {
+		t.Fatal("expected the same URL under a different subdomain to be unseen")
+	}
+	if !s.SeenURL("b.example.com", url) {
+		t.Fatal("expected repeat sighting under b.example.com to be seen")
+	}
+}
+
+func TestSeenURLPersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	s.SeenURL("a.example.com", "https://a.example.com/x")
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	s2, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s2.Close()
+
+	if !s2.SeenURL("a.example.com", "https://a.example.com/x") {
+		t.Error("expected URL seen before a close/reopen to still be seen")
+	}
+	if s2.SeenURL("a.example.com", "https://a.example.com/y") {
+		t.Error("expected a never-seen URL to be unseen after reopen")
+	}
+}
+
+func TestDoneAndMarkDone(t *testing.T) {
+	s := openTestStore(t)
+
+	if s.Done("a.example.com", "otx") {
+		t.Fatal("expected task to be incomplete before MarkDone")
+	}
+	s.MarkDone("a.example.com", "otx", 0)
+	if !s.Done("a.example.com", "otx") {
+		t.Fatal("expected task to be complete after MarkDone")
+	}
+	if s.Done("a.example.com", "wayback") {
+		t.Fatal("expected a different provider for the same domain to remain incomplete")
+	}
+}